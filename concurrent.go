@@ -0,0 +1,109 @@
+package sudoku
+
+import (
+	"context"
+	"sync"
+)
+
+// concurrentDepthThreshold is how many recursion levels BacktrackConcurrent
+// keeps splitting into goroutines before falling back to sequential
+// backtrack, where goroutine-spawn overhead would otherwise dominate.
+const concurrentDepthThreshold = 3
+
+// BacktrackConcurrent parallelises Backtrack by splitting the recursion at
+// its first empty cells: for each candidate value, a goroutine recurses on
+// its own copy of the board. workers bounds how many of these run at once,
+// via a buffered channel used as a semaphore. A context is cancelled once
+// maxSolutions solutions have been collected, so outstanding goroutines bail
+// out at their next recursion. Below concurrentDepthThreshold levels deep it
+// falls back to sequential backtrack, since goroutine-spawn overhead would
+// otherwise dominate close to the leaves.
+func BacktrackConcurrent(ab AnnotatedBoard, maxSolutions int, workers int) (solved bool, solutions []Board) {
+	solutions = []Board{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sem := make(chan struct{}, workers)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go backtrackConcurrent(ctx, cancel, ab, maxSolutions, 0, &mu, &solutions, sem, &wg)
+	wg.Wait()
+
+	return len(solutions) >= maxSolutions, solutions
+}
+
+func backtrackConcurrent(ctx context.Context, cancel context.CancelFunc, ab AnnotatedBoard, maxSolutions, depth int, mu *sync.Mutex, solutions *[]Board, sem chan struct{}, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	select {
+	case <-ctx.Done():
+		return
+	default:
+	}
+
+	var err error
+	ab, err = ab.Annotate()
+	if err != nil {
+		return
+	}
+	y, x, found := ab.Board.FirstEmpty()
+	if !found {
+		mu.Lock()
+		full := len(*solutions) >= maxSolutions
+		if !full {
+			*solutions = append(*solutions, ab.Board)
+			full = len(*solutions) >= maxSolutions
+		}
+		mu.Unlock()
+		if full {
+			cancel()
+		}
+		return
+	}
+
+	if depth >= concurrentDepthThreshold {
+		var found []Board
+		backtrack(ab, maxSolutions, &found)
+
+		mu.Lock()
+		for _, bo := range found {
+			if len(*solutions) >= maxSolutions {
+				break
+			}
+			*solutions = append(*solutions, bo)
+		}
+		full := len(*solutions) >= maxSolutions
+		mu.Unlock()
+		if full {
+			cancel()
+		}
+		return
+	}
+
+	for _, v := range ab.Candidates[y][x].Decimals() {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		child := ab.Copy()
+		child.Board[y][x] = v
+
+		wg.Add(1)
+		select {
+		case sem <- struct{}{}:
+			go func() {
+				defer func() { <-sem }()
+				backtrackConcurrent(ctx, cancel, child, maxSolutions, depth+1, mu, solutions, sem, wg)
+			}()
+		default:
+			// No free worker: recurse in place rather than blocking on sem,
+			// which would deadlock once every worker is itself waiting for
+			// a free slot to dispatch its own children.
+			backtrackConcurrent(ctx, cancel, child, maxSolutions, depth+1, mu, solutions, sem, wg)
+		}
+	}
+}
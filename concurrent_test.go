@@ -0,0 +1,64 @@
+package sudoku
+
+import "testing"
+
+func TestBacktrackConcurrentExhaustive(t *testing.T) {
+	for _, unsolved := range []Board{unsolved9b, unsolved4b} {
+		ab, _ := NewAnnotatedBoard(unsolved)
+		_, btSolutions := Backtrack(ab, 100)
+		_, bcSolutions := BacktrackConcurrent(ab, 100, 4)
+		if !sameSolutionSets(btSolutions, bcSolutions) {
+			t.Errorf("Expected BacktrackConcurrent and Backtrack to find the same solutions for:\n%+v", unsolved)
+		}
+	}
+}
+
+func TestBacktrackConcurrentRespectsMaxSolutions(t *testing.T) {
+	ab, _ := NewAnnotatedBoard(unsolved9)
+	solved, solutions := BacktrackConcurrent(ab, 4, 4)
+	if !solved {
+		t.Error("Expected board to be solved.")
+	}
+	if len(solutions) != 4 {
+		t.Errorf("Expected 4 solutions, got %d", len(solutions))
+	}
+
+	seen := map[string]bool{}
+	for _, bo := range solutions {
+		if !bo.Full() {
+			t.Errorf("Expected solution to be full:\n%+v", bo)
+		}
+		annotated, err := NewAnnotatedBoard(bo)
+		if err != nil || !annotated.Solved() {
+			t.Errorf("Expected solution to be valid:\n%+v", bo)
+		}
+		s, _ := bo.Short()
+		if seen[s] {
+			t.Errorf("Expected solutions not to contain duplicates:\n%+v", bo)
+		}
+		seen[s] = true
+	}
+}
+
+func TestBacktrackConcurrentSingleWorker(t *testing.T) {
+	ab, _ := NewAnnotatedBoard(unsolved9b)
+	_, btSolutions := Backtrack(ab, 100)
+	_, bcSolutions := BacktrackConcurrent(ab, 100, 1)
+	if !sameSolutionSets(btSolutions, bcSolutions) {
+		t.Error("Expected BacktrackConcurrent with a single worker to still find every solution.")
+	}
+}
+
+func BenchmarkBacktrack(b *testing.B) {
+	ab, _ := NewAnnotatedBoard(unsolved9)
+	for i := 0; i < b.N; i++ {
+		Backtrack(ab, 50)
+	}
+}
+
+func BenchmarkBacktrackConcurrent(b *testing.B) {
+	ab, _ := NewAnnotatedBoard(unsolved9)
+	for i := 0; i < b.N; i++ {
+		BacktrackConcurrent(ab, 50, 8)
+	}
+}
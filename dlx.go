@@ -0,0 +1,180 @@
+package sudoku
+
+// dlxNode is a cell in the toroidal doubly-linked list used by DLX. Column
+// headers are dlxNodes too (embedded in dlxColumn), linked horizontally into
+// the header row.
+type dlxNode struct {
+	left, right, up, down *dlxNode
+	col                   *dlxColumn
+	y, x, v               int
+}
+
+// dlxColumn is a constraint column. size counts the rows currently covering
+// it, which lets the search always branch on the most constrained column.
+type dlxColumn struct {
+	dlxNode
+	size int
+}
+
+// dlxMatrix holds the exact cover matrix for one sudoku: four constraint
+// families (cell, row, column, block), each contributing size² columns.
+type dlxMatrix struct {
+	header *dlxColumn
+	cols   []*dlxColumn
+	size   int
+}
+
+// newDLXMatrix builds the (empty) header row for a sudoku of provided size.
+func newDLXMatrix(size int) *dlxMatrix {
+	m := &dlxMatrix{header: &dlxColumn{}, size: size}
+	m.header.col = m.header
+	m.header.left, m.header.right = &m.header.dlxNode, &m.header.dlxNode
+
+	m.cols = make([]*dlxColumn, 4*size*size)
+	for i := range m.cols {
+		c := &dlxColumn{}
+		c.col = c
+		c.up, c.down = &c.dlxNode, &c.dlxNode
+		c.left = m.header.left
+		c.right = &m.header.dlxNode
+		m.header.left.right = &c.dlxNode
+		m.header.left = &c.dlxNode
+		m.cols[i] = c
+	}
+	return m
+}
+
+// addRow adds one candidate placement (field (y,x) set to v) as a row with
+// exactly four 1s, one per constraint family. blk is the block id (y,x)
+// belongs to, from Variant.blockIDs, so rectangular and jigsaw boards are
+// modeled just as well as square ones.
+func (m *dlxMatrix) addRow(y, x, v, blk int) {
+	size := m.size
+	idxs := [4]int{
+		y*size + x,
+		size*size + y*size + (v - 1),
+		2*size*size + x*size + (v - 1),
+		3*size*size + blk*size + (v - 1),
+	}
+
+	var nodes [4]*dlxNode
+	for i, idx := range idxs {
+		col := m.cols[idx]
+		n := &dlxNode{y: y, x: x, v: v, col: col}
+		n.down = &col.dlxNode
+		n.up = col.up
+		col.up.down = n
+		col.up = n
+		col.size++
+		nodes[i] = n
+	}
+	for i, n := range nodes {
+		n.left = nodes[(i+3)%4]
+		n.right = nodes[(i+1)%4]
+	}
+}
+
+// cover removes col from the header row and removes every row touching col
+// from their other columns.
+func cover(col *dlxColumn) {
+	col.right.left = col.left
+	col.left.right = col.right
+	for i := col.down; i != &col.dlxNode; i = i.down {
+		for j := i.right; j != i; j = j.right {
+			j.down.up = j.up
+			j.up.down = j.down
+			j.col.size--
+		}
+	}
+}
+
+// uncover reverses a prior cover, in the exact opposite order.
+func uncover(col *dlxColumn) {
+	for i := col.up; i != &col.dlxNode; i = i.up {
+		for j := i.left; j != i; j = j.left {
+			j.col.size++
+			j.down.up = j
+			j.up.down = j
+		}
+	}
+	col.right.left = &col.dlxNode
+	col.left.right = &col.dlxNode
+}
+
+// chooseColumn picks the column with the smallest size, the standard DLX
+// heuristic for minimising branching factor.
+func chooseColumn(header *dlxColumn) *dlxColumn {
+	best := header.right.col
+	for n := header.right; n != &header.dlxNode; n = n.right {
+		if n.col.size < best.size {
+			best = n.col
+		}
+	}
+	return best
+}
+
+// search recursively covers columns and picks rows, collecting every full
+// assignment it finds. It stops as soon as maxSolutions have been collected.
+func search(header *dlxColumn, maxSolutions int, solution []*dlxNode, solutions *[][]*dlxNode) bool {
+	if header.right == &header.dlxNode {
+		found := make([]*dlxNode, len(solution))
+		copy(found, solution)
+		*solutions = append(*solutions, found)
+		return len(*solutions) >= maxSolutions
+	}
+
+	col := chooseColumn(header)
+	cover(col)
+	for r := col.down; r != &col.dlxNode; r = r.down {
+		solution = append(solution, r)
+		for j := r.right; j != r; j = j.right {
+			cover(j.col)
+		}
+
+		if search(header, maxSolutions, solution, solutions) {
+			return true
+		}
+
+		solution = solution[:len(solution)-1]
+		for j := r.left; j != r; j = j.left {
+			uncover(j.col)
+		}
+	}
+	uncover(col)
+	return false
+}
+
+// DLX models the sudoku as an exact cover problem and solves it with Knuth's
+// Algorithm X via Dancing Links. It is usually a large speedup over
+// Backtrack on harder 9x9 and larger boards, since branching on the smallest
+// (most constrained) column prunes the search aggressively.
+func DLX(ab AnnotatedBoard, maxSolutions int) (solved bool, solutions []Board) {
+	size := ab.Size()
+	blockOf := ab.Variant.blockIDs(size)
+	m := newDLXMatrix(size)
+
+	for y, row := range ab.Board {
+		for x, v := range row {
+			values := []int{v}
+			if v == 0 {
+				values = ab.Candidates[y][x].Decimals()
+			}
+			for _, val := range values {
+				m.addRow(y, x, val, blockOf[y][x])
+			}
+		}
+	}
+
+	var found [][]*dlxNode
+	search(m.header, maxSolutions, nil, &found)
+
+	solutions = make([]Board, len(found))
+	for i, rows := range found {
+		bo := NewEmptyBoard(size)
+		for _, n := range rows {
+			bo[n.y][n.x] = n.v
+		}
+		solutions[i] = bo
+	}
+	return len(solutions) >= maxSolutions, solutions
+}
@@ -0,0 +1,61 @@
+package sudoku
+
+import "testing"
+
+// TestDLXExhaustive compares DLX against Backtrack when the full solution
+// set is small enough to enumerate (maxSolutions is never reached), so the
+// two algorithms' differing search order cannot make them disagree.
+func TestDLXExhaustive(t *testing.T) {
+	for _, unsolved := range []Board{unsolved9b, unsolved4b} {
+		ab, _ := NewAnnotatedBoard(unsolved)
+		btSolved, btSolutions := Backtrack(ab, 100)
+		dlxSolved, dlxSolutions := DLX(ab, 100)
+		if !sameSolutionSets(btSolutions, dlxSolutions) {
+			t.Errorf("Expected DLX and Backtrack to find the same solutions for:\n%+v", unsolved)
+		}
+		if dlxSolved != btSolved {
+			t.Errorf("Expected DLX's solved flag (%v) to match Backtrack's (%v): both should report whether maxSolutions was reached.", dlxSolved, btSolved)
+		}
+	}
+}
+
+// TestDLXValid checks DLX against the fully empty unsolved9, whose solution
+// space is far too large to enumerate, by requiring every returned board to
+// be a genuinely complete, conflict-free solution.
+func TestDLXValid(t *testing.T) {
+	ab, _ := NewAnnotatedBoard(unsolved9)
+	solved, solutions := DLX(ab, 4)
+	if !solved {
+		t.Error("Expected board to be solved.")
+	}
+	if len(solutions) != 4 {
+		t.Errorf("Expected 4 solutions, got %d", len(solutions))
+	}
+	for _, bo := range solutions {
+		if !bo.Full() {
+			t.Errorf("Expected solution to be full:\n%+v", bo)
+		}
+		solved, err := NewAnnotatedBoard(bo)
+		if err != nil || !solved.Solved() {
+			t.Errorf("Expected solution to be valid:\n%+v", bo)
+		}
+	}
+}
+
+func sameSolutionSets(a, b []Board) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := map[string]bool{}
+	for _, bo := range a {
+		s, _ := bo.Short()
+		set[s] = true
+	}
+	for _, bo := range b {
+		s, _ := bo.Short()
+		if !set[s] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,235 @@
+package sudoku
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A Format parses and encodes one of the string representations sudokus are
+// commonly distributed in, so callers don't have to hand-roll a parser for
+// every corpus they want to ingest. It complements the package's existing
+// Short/NewFromShort pair, which only covers that one notation.
+type Format interface {
+	Parse(s string) (Board, error)
+	Encode(bo Board) (string, error)
+}
+
+// Formats holds every built-in Format, keyed by name, for lookup by callers
+// that know which notation they're dealing with. NewFromAuto uses the same
+// map after sniffing the input. SDM isn't a Format itself (it's a file of
+// many line-format puzzles, not one parseable string) and is handled by
+// ParseSDM instead.
+var Formats = map[string]Format{
+	"line": lineFormat{},
+	"sdk":  sdkFormat{},
+	"json": jsonFormat{},
+}
+
+// runeToValue decodes a single grid character: '.' or '0' for empty, '1'-'9'
+// for the usual digits, and 'A' upward for values beyond 9 in larger boards.
+func runeToValue(r rune) (v int, ok bool) {
+	switch {
+	case r == '.' || r == '0':
+		return 0, true
+	case r >= '1' && r <= '9':
+		return int(r - '0'), true
+	case r >= 'A' && r <= 'Z':
+		return int(r-'A') + 10, true
+	}
+	return 0, false
+}
+
+// valueToRune is the inverse of runeToValue.
+func valueToRune(v int) rune {
+	switch {
+	case v == 0:
+		return '.'
+	case v <= 9:
+		return rune('0' + v)
+	default:
+		return rune('A' + v - 10)
+	}
+}
+
+// lineFormat is the canonical one-line format used by virtually every
+// sudoku corpus: size² characters, row-major, '.' or '0' for empty fields.
+// ParseSDM also builds on it, since an SDM file is simply one of these lines
+// per puzzle.
+type lineFormat struct{}
+
+func (lineFormat) Parse(s string) (bo Board, err error) {
+	s = strings.TrimSpace(s)
+	size := sqrt(len(s))
+	if size*size != len(s) {
+		return nil, fmt.Errorf("Malformed Line Notation: %s", s)
+	}
+
+	bo = NewEmptyBoard(size)
+	for i, r := range s {
+		v, ok := runeToValue(r)
+		if !ok {
+			return nil, fmt.Errorf("Malformed Line Notation: unexpected rune %q", r)
+		}
+		bo[i/size][i%size] = v
+	}
+	return bo, nil
+}
+
+func (lineFormat) Encode(bo Board) (string, error) {
+	var sb strings.Builder
+	for _, row := range bo {
+		for _, v := range row {
+			sb.WriteRune(valueToRune(v))
+		}
+	}
+	return sb.String(), nil
+}
+
+// ParseSDM parses an SDM file: one puzzle per line, in lineFormat, many
+// puzzles per file. Blank lines are skipped.
+func ParseSDM(r io.Reader) (boards []Board, err error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		bo, err := Formats["line"].Parse(line)
+		if err != nil {
+			return nil, err
+		}
+		boards = append(boards, bo)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return boards, nil
+}
+
+// sdkFormat is the SimpleSudoku ".ss"/SDK format: a grid spread over one
+// line per row, fields separated by whitespace and block boundaries marked
+// with "|", row blocks separated by "-"/"+" banner lines. Blank lines, banner
+// lines and comment lines starting with "#" are skipped; every other line is
+// read as a grid row.
+type sdkFormat struct{}
+
+func isBannerLine(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r != '-' && r != '+' && r != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+func (sdkFormat) Parse(s string) (bo Board, err error) {
+	var rows [][]int
+	for _, line := range strings.Split(s, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if isBannerLine(trimmed) || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		var row []int
+		for _, r := range trimmed {
+			if r == '|' || r == ' ' || r == '\t' {
+				continue
+			}
+			v, ok := runeToValue(r)
+			if !ok {
+				return nil, fmt.Errorf("Malformed SDK Notation: unexpected rune %q", r)
+			}
+			row = append(row, v)
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+
+	size := len(rows)
+	bo = NewEmptyBoard(size)
+	for y, row := range rows {
+		if len(row) != size {
+			return nil, fmt.Errorf("Malformed SDK Notation: row %d has %d fields, expected %d", y, len(row), size)
+		}
+		copy(bo[y], row)
+	}
+	return bo, nil
+}
+
+func (sdkFormat) Encode(bo Board) (string, error) {
+	size := bo.Size()
+	blockSize := sqrt(size)
+	bannerSegment := strings.Repeat("-", blockSize*2+1)
+	banner := strings.Repeat(bannerSegment+"+", blockSize)
+	banner = strings.TrimSuffix(banner, "+")
+
+	var sb strings.Builder
+	for y, row := range bo {
+		if y > 0 && y%blockSize == 0 {
+			sb.WriteString(banner + "\n")
+		}
+		for x, v := range row {
+			if x > 0 && x%blockSize == 0 {
+				sb.WriteString("|")
+			}
+			sb.WriteRune(valueToRune(v))
+			sb.WriteString(" ")
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n"), nil
+}
+
+// jsonBoard is the wire shape of the JSON format.
+type jsonBoard struct {
+	Size   int     `json:"size"`
+	Givens [][]int `json:"givens"`
+}
+
+// jsonFormat is the JSON form {"size":9,"givens":[[...],...]}.
+type jsonFormat struct{}
+
+func (jsonFormat) Parse(s string) (bo Board, err error) {
+	var jb jsonBoard
+	if err = json.Unmarshal([]byte(s), &jb); err != nil {
+		return nil, err
+	}
+	if len(jb.Givens) != jb.Size {
+		return nil, fmt.Errorf("Malformed JSON Notation: expected %d rows, got %d", jb.Size, len(jb.Givens))
+	}
+
+	bo = NewEmptyBoard(jb.Size)
+	for y, row := range jb.Givens {
+		if len(row) != jb.Size {
+			return nil, fmt.Errorf("Malformed JSON Notation: row %d has %d fields, expected %d", y, len(row), jb.Size)
+		}
+		copy(bo[y], row)
+	}
+	return bo, nil
+}
+
+func (jsonFormat) Encode(bo Board) (string, error) {
+	b, err := json.Marshal(jsonBoard{Size: bo.Size(), Givens: [][]int(bo)})
+	return string(b), err
+}
+
+// NewFromAuto sniffs which Format provided string is in and dispatches to
+// it: "{" for JSON, any newline for SDK, otherwise the one-line format.
+func NewFromAuto(s string) (Board, error) {
+	trimmed := strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(trimmed, "{"):
+		return Formats["json"].Parse(s)
+	case strings.Contains(trimmed, "\n"):
+		return Formats["sdk"].Parse(s)
+	default:
+		return Formats["line"].Parse(trimmed)
+	}
+}
@@ -0,0 +1,129 @@
+package sudoku
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLineFormat(t *testing.T) {
+	encoded, err := Formats["line"].Encode(solved9)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "987654321654321987321987654896745213745213896213896745579468132468132579132579468"
+	if encoded != expected {
+		t.Errorf("Expected line notations to match:\n%s\n%s", expected, encoded)
+	}
+
+	parsed, err := Formats["line"].Parse(encoded)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(solved9, parsed) {
+		t.Errorf("Expected parsed board to equal original:\n%+v\n%+v", solved9, parsed)
+	}
+
+	if _, err := Formats["line"].Parse("12"); err == nil {
+		t.Error("Expected error for a string whose length is not a perfect square.")
+	}
+	if _, err := Formats["line"].Parse("x23456789987654321123456789987654321123456789987654321123456789987654321123456789"); err == nil {
+		t.Error("Expected error for an unrecognised rune.")
+	}
+}
+
+func TestSDKFormat(t *testing.T) {
+	encoded, err := Formats["sdk"].Encode(unsolved9b)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(encoded, "|") || !strings.Contains(encoded, "-") {
+		t.Errorf("Expected SDK output to contain block separators:\n%s", encoded)
+	}
+
+	parsed, err := Formats["sdk"].Parse(encoded)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(unsolved9b, parsed) {
+		t.Errorf("Expected parsed board to equal original:\n%+v\n%+v", unsolved9b, parsed)
+	}
+}
+
+func TestSDKFormatIgnoresBanners(t *testing.T) {
+	withBanner := "# Some comment\n" + "1234\n3412\n" + "-----+-----\n" + "4123\n2341\n" + "# trailer\n"
+	parsed, err := Formats["sdk"].Parse(withBanner)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := Board{
+		{1, 2, 3, 4},
+		{3, 4, 1, 2},
+		{4, 1, 2, 3},
+		{2, 3, 4, 1},
+	}
+	if !reflect.DeepEqual(expected, parsed) {
+		t.Errorf("Expected %+v, got %+v", expected, parsed)
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	encoded, err := Formats["json"].Encode(unsolved4b)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	parsed, err := Formats["json"].Parse(encoded)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(unsolved4b, parsed) {
+		t.Errorf("Expected parsed board to equal original:\n%+v\n%+v", unsolved4b, parsed)
+	}
+
+	if _, err := Formats["json"].Parse(`{"size":4,"givens":[[1,2,3,4]]}`); err == nil {
+		t.Error("Expected error for a row count mismatching size.")
+	}
+}
+
+func TestParseSDM(t *testing.T) {
+	line, _ := Formats["line"].Encode(solved9)
+	file := line + "\n\n" + line + "\n"
+
+	boards, err := ParseSDM(strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(boards) != 2 {
+		t.Fatalf("Expected 2 boards, got %d", len(boards))
+	}
+	for _, bo := range boards {
+		if !reflect.DeepEqual(solved9, bo) {
+			t.Errorf("Expected %+v, got %+v", solved9, bo)
+		}
+	}
+}
+
+func TestNewFromAuto(t *testing.T) {
+	line, _ := Formats["line"].Encode(solved9)
+	sdk, _ := Formats["sdk"].Encode(unsolved9b)
+	js, _ := Formats["json"].Encode(unsolved4b)
+
+	for _, tc := range []struct {
+		name     string
+		input    string
+		expected Board
+	}{
+		{"line", line, solved9},
+		{"sdk", sdk, unsolved9b},
+		{"json", js, unsolved4b},
+	} {
+		bo, err := NewFromAuto(tc.input)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !reflect.DeepEqual(tc.expected, bo) {
+			t.Errorf("%s: expected %+v, got %+v", tc.name, tc.expected, bo)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package sudoku
+
+import (
+	"math/rand"
+	"time"
+)
+
+// A Difficulty grades how hard a generated puzzle is to solve, in terms of
+// which LogicalSolve techniques it requires. The zero value means "no
+// limit" wherever it is used as an option, following this package's
+// convention of treating values <= 0 as ignored.
+type Difficulty int
+
+const (
+	_ Difficulty = iota
+	// Easy puzzles solve with SingleCandidate alone.
+	Easy
+	// Medium puzzles additionally need CandidateLines and naked/hidden subsets.
+	Medium
+	// Hard puzzles additionally need X-Wing.
+	Hard
+	// Evil puzzles need Backtrack: no logical technique suffices.
+	Evil
+)
+
+// GenerateOptions configures Generate.
+type GenerateOptions struct {
+	// MinClues is the minimum number of givens that should remain.
+	// MinClues <= 0 is ignored (reduce as far as possible).
+	MinClues int
+	// Symmetric removes fields in rotationally symmetric pairs, so the
+	// result keeps 180° rotational symmetry (the center field of an
+	// odd-sized board has no partner and is removed on its own).
+	Symmetric bool
+	// MaxDifficulty rejects removals that would push the puzzle past this
+	// grade. MaxDifficulty <= 0 is ignored (no cap).
+	MaxDifficulty Difficulty
+	// Timeout aborts generation cleanly once exceeded, instead of trying
+	// every remaining field. Timeout <= 0 is ignored (no limit).
+	Timeout time.Duration
+	// Variant gives the block geometry to generate for, e.g. Variant6x6 or
+	// a jigsaw built with NewJigsaw. The zero value falls back to square
+	// sqrt(size) blocks.
+	Variant Variant
+}
+
+// removalGroups returns every field of a board with provided size, grouped
+// for removal: singly, or in rotationally symmetric pairs (or, for the
+// center field of an odd size, singly) if symmetric is set.
+func removalGroups(size int, symmetric bool) (groups [][]cell) {
+	seen := make(map[cell]bool, size*size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			c := cell{y, x}
+			if seen[c] {
+				continue
+			}
+			seen[c] = true
+			if !symmetric {
+				groups = append(groups, []cell{c})
+				continue
+			}
+			mirror := cell{size - 1 - y, size - 1 - x}
+			if mirror == c {
+				groups = append(groups, []cell{c})
+				continue
+			}
+			seen[mirror] = true
+			groups = append(groups, []cell{c, mirror})
+		}
+	}
+	return
+}
+
+// Generate generates a puzzle from a solved, random board by removing
+// fields (singly, or in symmetric groups if opts.Symmetric), keeping each
+// removal only if the remaining puzzle still has exactly one solution and,
+// if opts.MaxDifficulty is set, does not exceed it.
+func Generate(random Board, opts GenerateOptions) (unsolved Board) {
+	size := random.Size()
+	groups := removalGroups(size, opts.Symmetric)
+	for i := range groups {
+		j := rand.Intn(i + 1)
+		groups[i], groups[j] = groups[j], groups[i]
+	}
+
+	var deadline time.Time
+	if opts.Timeout > 0 {
+		deadline = time.Now().Add(opts.Timeout)
+	}
+	validMinimum := opts.MinClues > 0 && opts.MinClues < size*size
+
+	bo := random.Copy()
+	clues := size * size
+
+	for _, group := range groups {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			break
+		}
+		if validMinimum && clues <= opts.MinClues {
+			break
+		}
+		if validMinimum && clues-len(group) < opts.MinClues {
+			continue
+		}
+
+		removed := make([]int, len(group))
+		for i, c := range group {
+			removed[i] = bo[c.y][c.x]
+			bo[c.y][c.x] = 0
+		}
+
+		ab, _ := NewAnnotatedBoardWithVariant(bo, opts.Variant)
+		multiple, _ := Backtrack(ab, 2)
+		unique := !multiple
+		if unique && opts.MaxDifficulty > 0 && gradeDifficulty(ab) > opts.MaxDifficulty {
+			unique = false
+		}
+
+		if !unique {
+			for i, c := range group {
+				bo[c.y][c.x] = removed[i]
+			}
+			continue
+		}
+		clues -= len(group)
+	}
+	return bo
+}
+
+// GenerateSimple generates a board that is solvable with only single
+// candidates strategy. It is a thin wrapper around Generate, kept for
+// back-compat; minimum has the same meaning as GenerateOptions.MinClues.
+func GenerateSimple(random Board, minimum int) (unsolved Board) {
+	return Generate(random, GenerateOptions{MinClues: minimum, MaxDifficulty: Easy})
+}
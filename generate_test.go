@@ -0,0 +1,58 @@
+package sudoku
+
+import (
+	"testing"
+	"time"
+)
+
+func cluesOf(bo Board) (n int) {
+	for _, row := range bo {
+		for _, v := range row {
+			if v != 0 {
+				n++
+			}
+		}
+	}
+	return
+}
+
+func TestGenerateUnique(t *testing.T) {
+	solved := NewRandomBoard(9)
+	unsolved := Generate(solved, GenerateOptions{MinClues: 30})
+
+	ab, _ := NewAnnotatedBoard(unsolved)
+	_, solutions := Backtrack(ab, 2)
+	if len(solutions) != 1 {
+		t.Fatalf("Expected exactly one solution, got %d", len(solutions))
+	}
+	if cluesOf(unsolved) != 30 {
+		t.Errorf("Expected 30 clues to remain, got %d", cluesOf(unsolved))
+	}
+}
+
+func TestGenerateSymmetric(t *testing.T) {
+	size := 9
+	solved := NewRandomBoard(size)
+	unsolved := Generate(solved, GenerateOptions{MinClues: 30, Symmetric: true})
+
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			given := unsolved[y][x] != 0
+			mirrorGiven := unsolved[size-1-y][size-1-x] != 0
+			if given != mirrorGiven {
+				t.Fatalf("Expected (%d,%d) and its mirror to agree on being a given.", y, x)
+			}
+		}
+	}
+}
+
+func TestGenerateMaxDifficulty(t *testing.T) {
+	solved := NewRandomBoard(9)
+	unsolved := Generate(solved, GenerateOptions{MaxDifficulty: Easy, Timeout: 5 * time.Second})
+
+	ab, _ := NewAnnotatedBoard(unsolved)
+	solved2, _ := SingleCandidate(ab, 1)
+	if !solved2 {
+		t.Error("Expected puzzle capped at Easy to be solvable by SingleCandidate alone.")
+	}
+}
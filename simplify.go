@@ -0,0 +1,349 @@
+package sudoku
+
+// cell is a (y, x) coordinate pair, used to name groups of fields belonging
+// to the same unit.
+type cell struct {
+	y, x int
+}
+
+// units returns every row, column, and block of ab as a list of its cells,
+// deriving block membership from ab.Variant so rectangular and jigsaw
+// boards are grouped correctly.
+func units(ab AnnotatedBoard) (us [][]cell) {
+	size := ab.Size()
+
+	for y := 0; y < size; y++ {
+		var u []cell
+		for x := 0; x < size; x++ {
+			u = append(u, cell{y, x})
+		}
+		us = append(us, u)
+	}
+	for x := 0; x < size; x++ {
+		var u []cell
+		for y := 0; y < size; y++ {
+			u = append(u, cell{y, x})
+		}
+		us = append(us, u)
+	}
+
+	blockOf := ab.Variant.blockIDs(size)
+	blocks := make([][]cell, ab.Variant.blockCount(size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			blk := blockOf[y][x]
+			blocks[blk] = append(blocks[blk], cell{y, x})
+		}
+	}
+	us = append(us, blocks...)
+	return
+}
+
+// cellCombinations calls f with every combination of k cells out of cs.
+func cellCombinations(cs []cell, k int, f func(combo []cell)) {
+	n := len(cs)
+	if k > n {
+		return
+	}
+	combo := make([]cell, k)
+	var rec func(start, idx int)
+	rec = func(start, idx int) {
+		if idx == k {
+			f(combo)
+			return
+		}
+		for i := start; i <= n-(k-idx); i++ {
+			combo[idx] = cs[i]
+			rec(i+1, idx+1)
+		}
+	}
+	rec(0, 0)
+}
+
+// valueCombinations calls f with every combination of k values out of vs.
+func valueCombinations(vs []int, k int, f func(combo []int)) {
+	n := len(vs)
+	if k > n {
+		return
+	}
+	combo := make([]int, k)
+	var rec func(start, idx int)
+	rec = func(start, idx int) {
+		if idx == k {
+			f(combo)
+			return
+		}
+		for i := start; i <= n-(k-idx); i++ {
+			combo[idx] = vs[i]
+			rec(i+1, idx+1)
+		}
+	}
+	rec(0, 0)
+}
+
+// NakedSubset builds a Simplifier that looks, within each unit, for k cells
+// whose candidates only ever combine into k distinct values, and removes
+// those values from every other cell of the unit.
+func NakedSubset(k int) Simplifier {
+	return func(ab AnnotatedBoard) (ab2 AnnotatedBoard, succeeded bool) {
+		ab2 = ab.Copy()
+
+		for _, unit := range units(ab2) {
+			var open []cell
+			for _, c := range unit {
+				if !ab2.Candidates[c.y][c.x].Single() {
+					open = append(open, c)
+				}
+			}
+
+			cellCombinations(open, k, func(combo []cell) {
+				var union Candidates
+				for _, c := range combo {
+					union |= ab2.Candidates[c.y][c.x]
+				}
+				if popcount(union) != k {
+					return
+				}
+
+				inCombo := map[cell]bool{}
+				for _, c := range combo {
+					inCombo[c] = true
+				}
+				for _, c := range unit {
+					if inCombo[c] {
+						continue
+					}
+					before := ab2.Candidates[c.y][c.x]
+					if after := before &^ union; after != before {
+						ab2.Candidates[c.y][c.x] = after
+						succeeded = true
+					}
+				}
+			})
+		}
+		return
+	}
+}
+
+// HiddenSubset builds a Simplifier that looks, within each unit, for k
+// candidate values that only ever occur in k cells, and restricts those
+// cells to that set of values.
+func HiddenSubset(k int) Simplifier {
+	return func(ab AnnotatedBoard) (ab2 AnnotatedBoard, succeeded bool) {
+		ab2 = ab.Copy()
+
+		for _, unit := range units(ab2) {
+			var open []cell
+			seen := map[int]bool{}
+			var values []int
+			for _, c := range unit {
+				cs := ab2.Candidates[c.y][c.x]
+				if cs.Single() {
+					continue
+				}
+				open = append(open, c)
+				for _, v := range cs.Decimals() {
+					if !seen[v] {
+						seen[v] = true
+						values = append(values, v)
+					}
+				}
+			}
+
+			valueCombinations(values, k, func(combo []int) {
+				var mask Candidates
+				for _, v := range combo {
+					mask = mask.Add(v)
+				}
+
+				var inCells []cell
+				for _, c := range open {
+					if ab2.Candidates[c.y][c.x]&mask != 0 {
+						inCells = append(inCells, c)
+					}
+				}
+				if len(inCells) != k {
+					return
+				}
+
+				for _, c := range inCells {
+					before := ab2.Candidates[c.y][c.x]
+					if after := before & mask; after != before {
+						ab2.Candidates[c.y][c.x] = after
+						succeeded = true
+					}
+				}
+			})
+		}
+		return
+	}
+}
+
+// XWing finds, for each value, two rows (or columns) whose candidate
+// positions for that value fall on exactly the same two columns (or rows),
+// and eliminates the value from those columns (or rows) everywhere else.
+func XWing(ab AnnotatedBoard) (ab2 AnnotatedBoard, succeeded bool) {
+	ab2 = ab.Copy()
+	size := ab2.Size()
+
+	for _, v := range allCandidates(size).Decimals() {
+		lineCols := make([]Candidates, size)
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				if ab2.Candidates[y][x].Contains(v) {
+					lineCols[y] = lineCols[y].Add(x + 1)
+				}
+			}
+		}
+		if eliminateXWing(ab2, v, lineCols, false) {
+			succeeded = true
+		}
+
+		lineRows := make([]Candidates, size)
+		for x := 0; x < size; x++ {
+			for y := 0; y < size; y++ {
+				if ab2.Candidates[y][x].Contains(v) {
+					lineRows[x] = lineRows[x].Add(y + 1)
+				}
+			}
+		}
+		if eliminateXWing(ab2, v, lineRows, true) {
+			succeeded = true
+		}
+	}
+	return
+}
+
+// eliminateXWing looks for two lines (rows, or columns if transposed) whose
+// candidate positions for v are exactly the same pair of crossing lines, and
+// removes v from that pair everywhere outside the two lines.
+func eliminateXWing(ab2 AnnotatedBoard, v int, lines []Candidates, transposed bool) (succeeded bool) {
+	size := len(lines)
+	for l1 := 0; l1 < size; l1++ {
+		if popcount(lines[l1]) != 2 {
+			continue
+		}
+		for l2 := l1 + 1; l2 < size; l2++ {
+			if lines[l1] != lines[l2] {
+				continue
+			}
+			crossing := lines[l1].Decimals()
+			for l := 0; l < size; l++ {
+				if l == l1 || l == l2 {
+					continue
+				}
+				for _, oneBased := range crossing {
+					c := oneBased - 1
+					y, x := l, c
+					if transposed {
+						y, x = c, l
+					}
+					if ab2.Candidates[y][x].Contains(v) {
+						ab2.Candidates[y][x] = ab2.Candidates[y][x].Remove(v)
+						succeeded = true
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// Simplify repeatedly applies the provided simplifiers in order until none
+// of them make progress anymore (a fixpoint), returning whether any of them
+// ever succeeded.
+func Simplify(ab AnnotatedBoard, simps ...Simplifier) (ab2 AnnotatedBoard, succeeded bool) {
+	ab2 = ab
+	for {
+		progressed := false
+		for _, simp := range simps {
+			var ok bool
+			ab2, ok = simp(ab2)
+			progressed = progressed || ok
+		}
+		if !progressed {
+			return
+		}
+		succeeded = true
+	}
+}
+
+// mediumSimplifiers covers Medium-graded techniques: line-based elimination
+// plus naked and hidden subsets up to quads.
+var mediumSimplifiers = []Simplifier{
+	CandidateLines,
+	NakedSubset(2), NakedSubset(3), NakedSubset(4),
+	HiddenSubset(2), HiddenSubset(3), HiddenSubset(4),
+}
+
+// logicalSimplifiers is the full pipeline LogicalSolve drives: everything
+// mediumSimplifiers does, plus X-Wing.
+var logicalSimplifiers = append(append([]Simplifier{}, mediumSimplifiers...), XWing)
+
+// solveWithSimplifiers repeatedly fills in single candidates and runs simps
+// to a fixpoint, alternating until neither makes progress. It reports
+// whether that fully solved the board, alongside the furthest state reached
+// either way.
+func solveWithSimplifiers(ab AnnotatedBoard, simps []Simplifier) (ab2 AnnotatedBoard, solved bool) {
+	ab2, err := ab.Annotate()
+	if err != nil {
+		return ab2, false
+	}
+
+	for {
+		filled := false
+		for y, row := range ab2.Candidates {
+			for x, c := range row {
+				if ab2.Board[y][x] == 0 && c.Single() {
+					ab2.Board[y][x] = c.Decimals()[0]
+					filled = true
+				}
+			}
+		}
+		if ab2.Board.Full() {
+			break
+		}
+
+		ab2, err = ab2.Annotate()
+		if err != nil {
+			return ab2, false
+		}
+
+		var simplified bool
+		ab2, simplified = Simplify(ab2, simps...)
+		if !filled && !simplified {
+			return ab2, false
+		}
+	}
+
+	ab2, err = ab2.Annotate()
+	return ab2, err == nil && ab2.Solved()
+}
+
+// LogicalSolve tries to solve a board using only human-style logical
+// deduction (SingleCandidate plus the Simplify pipeline), falling back to
+// Backtrack only when those techniques get stuck. It is useful to grade a
+// puzzle's difficulty by which techniques it actually required.
+func LogicalSolve(ab AnnotatedBoard, maxSolutions int) (solved bool, solutions []Board) {
+	ab2, solvedFully := solveWithSimplifiers(ab, logicalSimplifiers)
+	if solvedFully {
+		return true, []Board{ab2.Board}
+	}
+	return Backtrack(ab2, maxSolutions)
+}
+
+// gradeDifficulty reports the easiest tier of logical technique that
+// suffices to solve ab, falling back to Evil if even the full
+// logicalSimplifiers pipeline gets stuck and Backtrack would be needed.
+func gradeDifficulty(ab AnnotatedBoard) Difficulty {
+	if _, solved := solveWithSimplifiers(ab, nil); solved {
+		return Easy
+	}
+	if _, solved := solveWithSimplifiers(ab, mediumSimplifiers); solved {
+		return Medium
+	}
+	if _, solved := solveWithSimplifiers(ab, logicalSimplifiers); solved {
+		return Hard
+	}
+	return Evil
+}
@@ -0,0 +1,135 @@
+package sudoku
+
+import "testing"
+
+func TestNakedSubset(t *testing.T) {
+	size := 9
+	ab := AnnotatedBoard{Board: NewEmptyBoard(size), Candidates: newBlockCandidates(size)}
+	full := allCandidates(size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			ab.Candidates[y][x] = full
+		}
+	}
+	// (0,0) and (0,1) are a naked pair restricted to {1,2}: both row 0 and
+	// block 0 (which contains both cells) should lose 1 and 2 everywhere
+	// else, but columns 0 and 1 (which each only contain one of the pair)
+	// should be untouched.
+	pair := Candidates(0).Add(1).Add(2)
+	ab.Candidates[0][0] = pair
+	ab.Candidates[0][1] = pair
+
+	ab2, succeeded := NakedSubset(2)(ab)
+	if !succeeded {
+		t.Fatal("Expected NakedSubset(2) to make progress.")
+	}
+	if ab2.Candidates[0][3].Contains(1) || ab2.Candidates[0][3].Contains(2) {
+		t.Error("Expected candidates 1 and 2 to be removed from row 0, col 3 (same row as the pair).")
+	}
+	if !ab2.Candidates[0][3].Contains(3) {
+		t.Error("Expected candidate 3 to remain in row 0, col 3 (not part of the pair).")
+	}
+	if ab2.Candidates[1][1].Contains(1) || ab2.Candidates[1][1].Contains(2) {
+		t.Error("Expected candidates 1 and 2 to be removed from row 1, col 1 (same block as the pair).")
+	}
+	if ab2.Candidates[0][0] != pair {
+		t.Errorf("Expected the pair cell itself to keep exactly {1,2}, got %v", ab2.Candidates[0][0].Decimals())
+	}
+	if !ab2.Candidates[3][0].Contains(1) {
+		t.Error("Expected candidate 1 to remain in row 3, col 0 (only shares a column with the pair).")
+	}
+}
+
+func TestHiddenSubset(t *testing.T) {
+	size := 9
+	ab := AnnotatedBoard{Board: NewEmptyBoard(size), Candidates: newBlockCandidates(size)}
+	full := allCandidates(size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			ab.Candidates[y][x] = full
+		}
+	}
+	// Candidates 1 and 2 only occur, within row 0, in cells (0,0) and
+	// (0,1): a hidden pair that should strip those two cells down to just
+	// {1,2}, leaving every other cell (in row 0 or otherwise) untouched.
+	for x := 2; x < size; x++ {
+		ab.Candidates[0][x] = ab.Candidates[0][x].Remove(1).Remove(2)
+	}
+
+	ab2, succeeded := HiddenSubset(2)(ab)
+	if !succeeded {
+		t.Fatal("Expected HiddenSubset(2) to make progress.")
+	}
+	want := Candidates(0).Add(1).Add(2)
+	if ab2.Candidates[0][0] != want {
+		t.Errorf("Expected row 0, col 0 to be restricted to {1,2}, got %v", ab2.Candidates[0][0].Decimals())
+	}
+	if ab2.Candidates[0][1] != want {
+		t.Errorf("Expected row 0, col 1 to be restricted to {1,2}, got %v", ab2.Candidates[0][1].Decimals())
+	}
+	if !ab2.Candidates[0][2].Contains(3) {
+		t.Error("Expected candidate 3 to remain in row 0, col 2 (not part of the hidden pair).")
+	}
+	if !ab2.Candidates[1][0].Contains(1) {
+		t.Error("Expected candidate 1 to remain in row 1, col 0 (untouched by the row-0 hidden pair).")
+	}
+}
+
+func TestXWing(t *testing.T) {
+	size := 9
+	ab := AnnotatedBoard{Board: NewEmptyBoard(size), Candidates: newBlockCandidates(size)}
+	full := allCandidates(size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			ab.Candidates[y][x] = full
+		}
+	}
+	// Rows 0 and 3 only allow candidate 5 in columns 2 and 6: a textbook
+	// X-Wing that should remove 5 from column 2 and 6 everywhere else.
+	for _, y := range []int{0, 3} {
+		for x := 0; x < size; x++ {
+			if x != 2 && x != 6 {
+				ab.Candidates[y][x] = ab.Candidates[y][x].Remove(5)
+			}
+		}
+	}
+
+	ab2, succeeded := XWing(ab)
+	if !succeeded {
+		t.Error("Expected XWing to make progress.")
+	}
+	if ab2.Candidates[5][2].Contains(5) {
+		t.Error("Expected candidate 5 to be removed from row 5, col 2.")
+	}
+	if ab2.Candidates[5][6].Contains(5) {
+		t.Error("Expected candidate 5 to be removed from row 5, col 6.")
+	}
+	if !ab2.Candidates[0][2].Contains(5) {
+		t.Error("Expected candidate 5 to remain in row 0, col 2 (part of the X-Wing).")
+	}
+	if !ab2.Candidates[5][3].Contains(5) {
+		t.Error("Expected candidate 5 to remain in row 5, col 3 (not part of the X-Wing's columns).")
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	ab, _ := NewAnnotatedBoard(unsolved9b)
+	ab2, succeeded := Simplify(ab, CandidateLines, NakedSubset(2), HiddenSubset(2), XWing)
+	if !succeeded {
+		t.Error("Expected Simplify to make progress on unsolved9b.")
+	}
+	if ab2.Candidates[4][1].Contains(8) {
+		t.Error("Expected Simplify to have removed candidate 8 from row 4, col 1, same as CandidateLines alone.")
+	}
+}
+
+func TestLogicalSolve(t *testing.T) {
+	ab, _ := NewAnnotatedBoard(unsolved4b)
+	solved, solutions := LogicalSolve(ab, 1)
+	if !solved {
+		t.Error("Expected board to be solved.")
+	}
+	if len(solutions) != 1 || solutions[0].Full() != true {
+		t.Errorf("Expected a single, full solution, got %+v", solutions)
+	}
+}
@@ -131,22 +131,24 @@ func (bo Board) UltraShort() (s string, err error) {
 	return
 }
 
-// Candidates contains all "penciled" numbers that may occupy a field.
-type Candidates int
+// Candidates contains all "penciled" numbers that may occupy a field. It is
+// a uint64 rather than an int so boards up to 16x16 (needing candidate bit
+// 16) fit.
+type Candidates uint64
 
 // Add adds provided number to candidates (if not exists).
 func (c Candidates) Add(v int) Candidates {
-	return c | 1<<uint(v)
+	return c | Candidates(1)<<uint(v)
 }
 
 // Remove provided number from candidates (if exists).
 func (c Candidates) Remove(v int) Candidates {
-	return c &^ (1 << uint(v))
+	return c &^ (Candidates(1) << uint(v))
 }
 
 // Contains checks whether candidates contain provided number.
 func (c Candidates) Contains(v int) bool {
-	return c&(1<<uint(v)) != 0
+	return c&(Candidates(1)<<uint(v)) != 0
 }
 
 // Single returns whether candidates contain a single candidate.
@@ -177,6 +179,7 @@ func (c Candidates) Complement(size int) Candidates {
 type AnnotatedBoard struct {
 	Board
 	Candidates [][]Candidates
+	Variant    Variant
 }
 
 // Copy returns a copy of the annotated board. Helpful to stay as immutible as possible for now.
@@ -188,12 +191,21 @@ func (ab AnnotatedBoard) Copy() (ab2 AnnotatedBoard) {
 		copy(ab2.Candidates[i], ab.Candidates[i])
 	}
 	ab2.Board = ab.Board.Copy()
+	ab2.Variant = ab.Variant
 	return
 }
 
-// NewAnnotatedBoard returns an annotated version of provided board.
+// NewAnnotatedBoard returns an annotated version of provided board, using
+// the default square-rooted block geometry for back-compat.
 func NewAnnotatedBoard(bo Board) (ab AnnotatedBoard, err error) {
-	ab = AnnotatedBoard{Board: bo.Copy()}
+	return NewAnnotatedBoardWithVariant(bo, Variant{})
+}
+
+// NewAnnotatedBoardWithVariant returns an annotated version of provided
+// board, deriving block membership from variant instead of assuming square
+// sqrt(size) blocks. A zero Variant behaves exactly like NewAnnotatedBoard.
+func NewAnnotatedBoardWithVariant(bo Board, variant Variant) (ab AnnotatedBoard, err error) {
+	ab = AnnotatedBoard{Board: bo.Copy(), Variant: variant}
 	return ab.Annotate()
 }
 
@@ -210,24 +222,25 @@ func (ab AnnotatedBoard) Solved() bool {
 }
 
 // Annotate (naively) annotates a board with possible candidates for each field.
-// All data except board will be overwritten.
+// All data except board and Variant will be overwritten.
 func (ab AnnotatedBoard) Annotate() (AnnotatedBoard, error) {
 	ab.Board = ab.Board.Copy()
 	size := ab.Size()
-	rt := sqrt(size)
+	blockOf := ab.Variant.blockIDs(size)
 	rows, cols := make([]Candidates, size), make([]Candidates, size)
 	ab.Candidates = newBlockCandidates(size)
-	blocks := newBlockCandidates(rt)
+	blocks := make([]Candidates, ab.Variant.blockCount(size))
 	for y, row := range ab.Board {
 		for x, v := range row {
 			if v > 0 {
-				if rows[y].Contains(v) || cols[x].Contains(v) || blocks[y/rt][x/rt].Contains(v) {
+				blk := blockOf[y][x]
+				if rows[y].Contains(v) || cols[x].Contains(v) || blocks[blk].Contains(v) {
 					return ab, errors.New("Not Solvable.")
 				}
 				ab.Candidates[y][x] = ab.Candidates[y][x].Add(v)
 				rows[y] = rows[y].Add(v)
 				cols[x] = cols[x].Add(v)
-				blocks[y/rt][x/rt] = blocks[y/rt][x/rt].Add(v)
+				blocks[blk] = blocks[blk].Add(v)
 			}
 		}
 	}
@@ -236,7 +249,7 @@ func (ab AnnotatedBoard) Annotate() (AnnotatedBoard, error) {
 			if v > 1 {
 				continue
 			}
-			ab.Candidates[y][x] = allCandidates(size) &^ rows[y] &^ cols[x] &^ blocks[y/rt][x/rt]
+			ab.Candidates[y][x] = allCandidates(size) &^ rows[y] &^ cols[x] &^ blocks[blockOf[y][x]]
 		}
 	}
 	return ab, nil
@@ -306,53 +319,62 @@ type Simplifier func(ab AnnotatedBoard) (ab2 AnnotatedBoard, succeeded bool)
 func CandidateLines(ab AnnotatedBoard) (ab2 AnnotatedBoard, succeeded bool) {
 	ab2 = ab.Copy()
 	size := ab2.Size()
-	blockSize := sqrt(size)
+
+	// Group cells by block, via Variant, so rectangular and jigsaw blocks
+	// work the same as square ones.
+	blockOf := ab2.Variant.blockIDs(size)
+	blockCells := make([][]cell, ab2.Variant.blockCount(size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			blk := blockOf[y][x]
+			blockCells[blk] = append(blockCells[blk], cell{y, x})
+		}
+	}
 
 	// Iterate over blocks.
-	for blkY := 0; blkY < blockSize; blkY++ {
-		for blkX := 0; blkX < blockSize; blkX++ {
-			// Build other rows and cols for easier removal of found candidates from them.
-			rowsNotInBlock, colsNotInBlock := allCandidates(size), allCandidates(size)
-			// Build maps for indices per candidates (if a candidate is in a single row/col - that will be a win!).
-			inRows, inCols := map[int]Candidates{}, map[int]Candidates{}
-			// Iterate over rows in block.
-			for yInBlk := 0; yInBlk < blockSize; yInBlk++ {
-				y := blkY*blockSize + yInBlk
-				rowsNotInBlock = rowsNotInBlock.Remove(y + 1)
-				// Iterate over cols in block.
-				for xInBlk := 0; xInBlk < blockSize; xInBlk++ {
-					x := blkX*blockSize + xInBlk
-					colsNotInBlock = colsNotInBlock.Remove(x + 1)
-					cs := ab2.Candidates[y][x]
-					// Add one-based candidate indices to maps.
-					for _, c := range cs.Decimals() {
-						inRows[c] = inRows[c].Add(y + 1)
-						inCols[c] = inCols[c].Add(x + 1)
-					}
-				}
+	for _, cells := range blockCells {
+		inBlock := map[cell]bool{}
+		// Build other rows and cols for easier removal of found candidates from them.
+		rowsInBlock, colsInBlock := Candidates(0), Candidates(0)
+		// Build maps for indices per candidates (if a candidate is in a single row/col - that will be a win!).
+		inRows, inCols := map[int]Candidates{}, map[int]Candidates{}
+		for _, c := range cells {
+			inBlock[c] = true
+			rowsInBlock = rowsInBlock.Add(c.y + 1)
+			colsInBlock = colsInBlock.Add(c.x + 1)
+			// Add one-based candidate indices to maps.
+			for _, v := range ab2.Candidates[c.y][c.x].Decimals() {
+				inRows[v] = inRows[v].Add(c.y + 1)
+				inCols[v] = inCols[v].Add(c.x + 1)
 			}
-
-			// Remove found line candidates from other lines.
-			for c, cols := range inCols {
-				if cols.Single() {
-					col := cols.Decimals()[0] - 1
-					for _, row := range rowsNotInBlock.Decimals() {
-						if ab2.Candidates[row-1][col].Contains(c) {
-							succeeded = true
-							ab2.Candidates[row-1][col] = ab2.Candidates[row-1][col].Remove(c)
-						}
+		}
+		rowsNotInBlock := allCandidates(size) &^ rowsInBlock
+		colsNotInBlock := allCandidates(size) &^ colsInBlock
+
+		// Remove found line candidates from other lines.
+		for v, cols := range inCols {
+			if cols.Single() {
+				col := cols.Decimals()[0] - 1
+				for _, row := range rowsNotInBlock.Decimals() {
+					c := cell{row - 1, col}
+					if inBlock[c] || !ab2.Candidates[c.y][c.x].Contains(v) {
+						continue
 					}
+					succeeded = true
+					ab2.Candidates[c.y][c.x] = ab2.Candidates[c.y][c.x].Remove(v)
 				}
 			}
-			for c, rows := range inRows {
-				if rows.Single() {
-					row := rows.Decimals()[0] - 1
-					for _, col := range colsNotInBlock.Decimals() {
-						if ab2.Candidates[row][col-1].Contains(c) {
-							succeeded = true
-							ab2.Candidates[row][col-1] = ab2.Candidates[row][col-1].Remove(c)
-						}
+		}
+		for v, rows := range inRows {
+			if rows.Single() {
+				row := rows.Decimals()[0] - 1
+				for _, col := range colsNotInBlock.Decimals() {
+					c := cell{row, col - 1}
+					if inBlock[c] || !ab2.Candidates[c.y][c.x].Contains(v) {
+						continue
 					}
+					succeeded = true
+					ab2.Candidates[c.y][c.x] = ab2.Candidates[c.y][c.x].Remove(v)
 				}
 			}
 		}
@@ -361,42 +383,6 @@ func CandidateLines(ab AnnotatedBoard) (ab2 AnnotatedBoard, succeeded bool) {
 	return
 }
 
-// GenerateSimple generates a board that is solvable with only single candidates strategy.
-// Minimum param sets the number of minimum numbers that should remain on sudoku.
-// minimum <= 0 will be ignored (hardest) and the higher the easier it gets.
-// minimum >= size² makes no sense.
-func GenerateSimple(random Board, minimum int) (unsolved Board) {
-	size := random.Size()
-	fields := [][3]int{}
-	for y := 0; y < size; y++ {
-		for x := 0; x < size; x++ {
-			fields = append(fields, [3]int{y, x, random[y][x]})
-		}
-	}
-
-	for i := range fields {
-		j := rand.Intn(i + 1)
-		fields[i], fields[j] = fields[j], fields[i]
-	}
-
-	ab, _ := NewAnnotatedBoard(random)
-	fieldCount := len(fields)
-	validMinimum := minimum > 0 && minimum < fieldCount
-
-	for i, f := range fields {
-		ab.Board[f[0]][f[1]] = 0
-		ab, _ = ab.Annotate()
-		solvable, _ := SingleCandidate(ab, 1)
-		if !solvable {
-			ab.Board[f[0]][f[1]] = f[2]
-		}
-		if validMinimum && (fieldCount-i-1) == minimum {
-			return ab.Board
-		}
-	}
-	return ab.Board
-}
-
 // Helpers
 
 // allCandidates returns all candidates for a field of a sudoku with provided size.
@@ -428,6 +414,17 @@ func log2(i int) int {
 	return int(math.Log2(float64(i)))
 }
 
+// popcount returns the number of candidates set, using Kernighan's trick of
+// clearing the lowest set bit until none are left.
+func popcount(c Candidates) (n int) {
+	i := int(c)
+	for i != 0 {
+		i &= i - 1
+		n++
+	}
+	return
+}
+
 func b(i Candidates) string {
 	return strconv.FormatInt(int64(i), 2)
 }
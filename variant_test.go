@@ -0,0 +1,97 @@
+package sudoku
+
+import (
+	"reflect"
+	"testing"
+)
+
+var solved6 = Board{
+	{1, 2, 3, 4, 5, 6},
+	{4, 5, 6, 1, 2, 3},
+	{2, 3, 1, 5, 6, 4},
+	{5, 6, 4, 2, 3, 1},
+	{3, 1, 2, 6, 4, 5},
+	{6, 4, 5, 3, 1, 2},
+}
+
+var unsolved6 = Board{
+	{1, 0, 3, 0, 5, 6},
+	{0, 5, 6, 1, 0, 3},
+	{2, 3, 1, 5, 6, 4},
+	{5, 6, 4, 2, 3, 1},
+	{3, 1, 0, 6, 4, 0},
+	{6, 4, 5, 0, 1, 2},
+}
+
+// jigsawBlockMap partitions a 4x4 board into four non-rectangular blocks.
+var jigsawBlockMap = [][]int{
+	{0, 0, 0, 1},
+	{0, 1, 1, 1},
+	{2, 2, 3, 3},
+	{2, 2, 3, 3},
+}
+
+var jigsawSolved = Board{
+	{1, 2, 3, 4},
+	{4, 3, 1, 2},
+	{2, 1, 4, 3},
+	{3, 4, 2, 1},
+}
+
+var jigsawUnsolved = Board{
+	{1, 0, 3, 0},
+	{4, 3, 0, 2},
+	{0, 1, 4, 3},
+	{3, 4, 2, 0},
+}
+
+func TestVariant6x6Annotate(t *testing.T) {
+	ab, err := NewAnnotatedBoardWithVariant(solved6, Variant6x6)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !ab.Solved() {
+		t.Error("Expected a fully filled, valid 6x6 board to be Solved.")
+	}
+}
+
+func TestVariant6x6Solve(t *testing.T) {
+	ab, err := NewAnnotatedBoardWithVariant(unsolved6, Variant6x6)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, solutions := Backtrack(ab, 2)
+	if len(solutions) != 1 {
+		t.Fatalf("Expected exactly one solution, got %d", len(solutions))
+	}
+	if !reflect.DeepEqual(solutions[0], solved6) {
+		t.Errorf("Expected %+v, got %+v", solved6, solutions[0])
+	}
+}
+
+func TestJigsawSolve(t *testing.T) {
+	variant := NewJigsaw(jigsawBlockMap)
+	ab, err := NewAnnotatedBoardWithVariant(jigsawUnsolved, variant)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, solutions := Backtrack(ab, 2)
+	if len(solutions) != 1 {
+		t.Fatalf("Expected exactly one solution, got %d", len(solutions))
+	}
+	if !reflect.DeepEqual(solutions[0], jigsawSolved) {
+		t.Errorf("Expected %+v, got %+v", jigsawSolved, solutions[0])
+	}
+}
+
+func TestJigsawRejectsBlockDuplicate(t *testing.T) {
+	variant := NewJigsaw(jigsawBlockMap)
+	bad := NewEmptyBoard(4)
+	// (0,3) and (1,1) share block 1 but no row or column, so the same value
+	// in both breaks only the block constraint.
+	bad[0][3] = 4
+	bad[1][1] = 4
+	if _, err := NewAnnotatedBoardWithVariant(bad, variant); err == nil {
+		t.Error("Expected an error for a block-constraint-violating jigsaw board.")
+	}
+}